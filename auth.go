@@ -0,0 +1,86 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Authenticator signs an outgoing request before it is sent to the
+// Checkly API. Implementations must be safe for concurrent use, since a
+// client may have multiple requests in flight at once.
+type Authenticator interface {
+	AuthenticateRequest(req *http.Request) error
+}
+
+// StaticTokenAuth authenticates every request with a fixed API key.
+type StaticTokenAuth struct {
+	Token string
+}
+
+// AuthenticateRequest sets the Authorization header to "Bearer <Token>".
+func (a StaticTokenAuth) AuthenticateRequest(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// EnvTokenAuth reads the API key from an environment variable on every
+// request, so a rotated key takes effect without restarting the process.
+type EnvTokenAuth struct {
+	// EnvVar is the environment variable to read. Defaults to
+	// "CHECKLY_API_KEY" if empty.
+	EnvVar string
+}
+
+// AuthenticateRequest sets the Authorization header from the configured
+// environment variable. It returns an error if the variable is unset.
+func (a EnvTokenAuth) AuthenticateRequest(req *http.Request) error {
+	envVar := a.EnvVar
+	if envVar == "" {
+		envVar = "CHECKLY_API_KEY"
+	}
+	token := getEnv(envVar, "")
+	if token == "" {
+		return fmt.Errorf("checkly: environment variable %s is not set", envVar)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// RefreshingTokenAuth authenticates requests with a short-lived token,
+// calling refresh to obtain a new one once the current one is past its
+// expiry. refresh returns the token, its expiry time, and an error.
+type RefreshingTokenAuth struct {
+	refresh func(ctx context.Context) (string, time.Time, error)
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewRefreshingTokenAuth returns a RefreshingTokenAuth backed by refresh.
+func NewRefreshingTokenAuth(refresh func(ctx context.Context) (string, time.Time, error)) *RefreshingTokenAuth {
+	return &RefreshingTokenAuth{refresh: refresh}
+}
+
+// AuthenticateRequest sets the Authorization header, refreshing the
+// token first if it is missing or expired. The request's own context is
+// used for the refresh call, so a request-scoped timeout also bounds the
+// refresh.
+func (a *RefreshingTokenAuth) AuthenticateRequest(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == "" || !a.expiry.IsZero() && time.Now().After(a.expiry) {
+		token, expiry, err := a.refresh(req.Context())
+		if err != nil {
+			return fmt.Errorf("checkly: refreshing token: %w", err)
+		}
+		a.token = token
+		a.expiry = expiry
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
@@ -0,0 +1,392 @@
+package checkly
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrBulkSkipped is the Err recorded for a bulk operation item that was
+// never attempted because an earlier item failed and opts.StopOnFirstError
+// was set.
+var ErrBulkSkipped = errors.New("checkly: skipped because an earlier bulk item failed")
+
+// BulkOptions controls how a bulk operation parallelizes its underlying
+// one-at-a-time API calls.
+type BulkOptions struct {
+	// Concurrency bounds how many requests are in flight at once. Values
+	// <= 0 default to 1 (sequential).
+	Concurrency int
+	// StopOnFirstError cancels in-flight and not-yet-started work as soon
+	// as one item fails. Already-started requests are cancelled via
+	// context and may still report a result (success or error) if they
+	// complete before noticing the cancellation; items that never start
+	// are reported with Err set to ErrBulkSkipped.
+	StopOnFirstError bool
+	// RetryPolicy, if set, overrides the client's RetryPolicy for the
+	// duration of the bulk operation only.
+	RetryPolicy *RetryPolicy
+}
+
+func (o BulkOptions) concurrency() int {
+	if o.Concurrency > 0 {
+		return o.Concurrency
+	}
+	return 1
+}
+
+// runBulk fans n items of work out across a bounded worker pool,
+// preserving the caller's input ordering in the result slice regardless
+// of completion order. work is called once per index in [0, n) that is
+// actually dispatched; onSkip is called once, in order, for every index
+// that is never dispatched because an earlier item failed (with
+// StopOnFirstError set) or ctx was done.
+func runBulk(ctx context.Context, n int, opts BulkOptions, work func(ctx context.Context, i int) error, onSkip func(i int)) {
+	if n == 0 {
+		return
+	}
+	if opts.RetryPolicy != nil {
+		ctx = withRetryPolicyOverride(ctx, opts.RetryPolicy)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, opts.concurrency())
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var stopped bool
+
+	dispatch := func(i int) bool {
+		mu.Lock()
+		stop := stopped
+		mu.Unlock()
+		if stop {
+			return false
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := work(ctx, i); err != nil && opts.StopOnFirstError {
+				mu.Lock()
+				stopped = true
+				mu.Unlock()
+				cancel()
+			}
+		}()
+		return true
+	}
+
+	for i := 0; i < n; i++ {
+		if !dispatch(i) {
+			onSkip(i)
+		}
+	}
+
+	wg.Wait()
+}
+
+// CheckBulkResult is the per-item outcome of a Check bulk operation.
+type CheckBulkResult struct {
+	Index    int
+	Resource *Check
+	Err      error
+}
+
+// CheckUpdate pairs a check ID with the check data to write, for use
+// with UpdateBulk.
+type CheckUpdate struct {
+	ID    string
+	Check Check
+}
+
+// CreateBulk creates checks concurrently, bounded by opts.Concurrency,
+// and reports one CheckBulkResult per input check in the same order.
+func (c *client) CreateBulk(ctx context.Context, checks []Check, opts BulkOptions) []CheckBulkResult {
+	results := make([]CheckBulkResult, len(checks))
+	runBulk(ctx, len(checks), opts, func(ctx context.Context, i int) error {
+		created, err := c.Create(ctx, checks[i])
+		results[i] = CheckBulkResult{Index: i, Resource: created, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = CheckBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// UpdateBulk updates checks concurrently, bounded by opts.Concurrency,
+// and reports one CheckBulkResult per input update in the same order.
+func (c *client) UpdateBulk(ctx context.Context, updates []CheckUpdate, opts BulkOptions) []CheckBulkResult {
+	results := make([]CheckBulkResult, len(updates))
+	runBulk(ctx, len(updates), opts, func(ctx context.Context, i int) error {
+		updated, err := c.Update(ctx, updates[i].ID, updates[i].Check)
+		results[i] = CheckBulkResult{Index: i, Resource: updated, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = CheckBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// DeleteBulk deletes checks concurrently, bounded by opts.Concurrency,
+// and reports one CheckBulkResult (with a nil Resource) per input ID in
+// the same order.
+func (c *client) DeleteBulk(ctx context.Context, ids []string, opts BulkOptions) []CheckBulkResult {
+	results := make([]CheckBulkResult, len(ids))
+	runBulk(ctx, len(ids), opts, func(ctx context.Context, i int) error {
+		err := c.Delete(ctx, ids[i])
+		results[i] = CheckBulkResult{Index: i, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = CheckBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// GroupBulkResult is the per-item outcome of a Group bulk operation.
+type GroupBulkResult struct {
+	Index    int
+	Resource *Group
+	Err      error
+}
+
+// GroupUpdate pairs a group ID with the group data to write, for use
+// with UpdateGroupBulk.
+type GroupUpdate struct {
+	ID    int64
+	Group Group
+}
+
+// CreateGroupBulk creates check groups concurrently, bounded by
+// opts.Concurrency, and reports one GroupBulkResult per input group in
+// the same order.
+func (c *client) CreateGroupBulk(ctx context.Context, groups []Group, opts BulkOptions) []GroupBulkResult {
+	results := make([]GroupBulkResult, len(groups))
+	runBulk(ctx, len(groups), opts, func(ctx context.Context, i int) error {
+		created, err := c.CreateGroup(ctx, groups[i])
+		results[i] = GroupBulkResult{Index: i, Resource: created, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = GroupBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// UpdateGroupBulk updates check groups concurrently, bounded by
+// opts.Concurrency, and reports one GroupBulkResult per input update in
+// the same order.
+func (c *client) UpdateGroupBulk(ctx context.Context, updates []GroupUpdate, opts BulkOptions) []GroupBulkResult {
+	results := make([]GroupBulkResult, len(updates))
+	runBulk(ctx, len(updates), opts, func(ctx context.Context, i int) error {
+		updated, err := c.UpdateGroup(ctx, updates[i].ID, updates[i].Group)
+		results[i] = GroupBulkResult{Index: i, Resource: updated, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = GroupBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// DeleteGroupBulk deletes check groups concurrently, bounded by
+// opts.Concurrency, and reports one GroupBulkResult (with a nil
+// Resource) per input ID in the same order.
+func (c *client) DeleteGroupBulk(ctx context.Context, ids []int64, opts BulkOptions) []GroupBulkResult {
+	results := make([]GroupBulkResult, len(ids))
+	runBulk(ctx, len(ids), opts, func(ctx context.Context, i int) error {
+		err := c.DeleteGroup(ctx, ids[i])
+		results[i] = GroupBulkResult{Index: i, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = GroupBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// SnippetBulkResult is the per-item outcome of a Snippet bulk operation.
+type SnippetBulkResult struct {
+	Index    int
+	Resource *Snippet
+	Err      error
+}
+
+// SnippetUpdate pairs a snippet ID with the snippet data to write, for
+// use with UpdateSnippetBulk.
+type SnippetUpdate struct {
+	ID      int64
+	Snippet Snippet
+}
+
+// CreateSnippetBulk creates snippets concurrently, bounded by
+// opts.Concurrency, and reports one SnippetBulkResult per input snippet
+// in the same order.
+func (c *client) CreateSnippetBulk(ctx context.Context, snippets []Snippet, opts BulkOptions) []SnippetBulkResult {
+	results := make([]SnippetBulkResult, len(snippets))
+	runBulk(ctx, len(snippets), opts, func(ctx context.Context, i int) error {
+		created, err := c.CreateSnippet(ctx, snippets[i])
+		results[i] = SnippetBulkResult{Index: i, Resource: created, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = SnippetBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// UpdateSnippetBulk updates snippets concurrently, bounded by
+// opts.Concurrency, and reports one SnippetBulkResult per input update
+// in the same order.
+func (c *client) UpdateSnippetBulk(ctx context.Context, updates []SnippetUpdate, opts BulkOptions) []SnippetBulkResult {
+	results := make([]SnippetBulkResult, len(updates))
+	runBulk(ctx, len(updates), opts, func(ctx context.Context, i int) error {
+		updated, err := c.UpdateSnippet(ctx, updates[i].ID, updates[i].Snippet)
+		results[i] = SnippetBulkResult{Index: i, Resource: updated, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = SnippetBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// DeleteSnippetBulk deletes snippets concurrently, bounded by
+// opts.Concurrency, and reports one SnippetBulkResult (with a nil
+// Resource) per input ID in the same order.
+func (c *client) DeleteSnippetBulk(ctx context.Context, ids []int64, opts BulkOptions) []SnippetBulkResult {
+	results := make([]SnippetBulkResult, len(ids))
+	runBulk(ctx, len(ids), opts, func(ctx context.Context, i int) error {
+		err := c.DeleteSnippet(ctx, ids[i])
+		results[i] = SnippetBulkResult{Index: i, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = SnippetBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// EnvironmentVariableBulkResult is the per-item outcome of an
+// EnvironmentVariable bulk operation.
+type EnvironmentVariableBulkResult struct {
+	Index    int
+	Resource *EnvironmentVariable
+	Err      error
+}
+
+// EnvironmentVariableUpdate pairs an environment variable key with the
+// value to write, for use with UpdateEnvironmentVariableBulk.
+type EnvironmentVariableUpdate struct {
+	Key                 string
+	EnvironmentVariable EnvironmentVariable
+}
+
+// CreateEnvironmentVariableBulk creates environment variables
+// concurrently, bounded by opts.Concurrency, and reports one
+// EnvironmentVariableBulkResult per input variable in the same order.
+func (c *client) CreateEnvironmentVariableBulk(ctx context.Context, envVars []EnvironmentVariable, opts BulkOptions) []EnvironmentVariableBulkResult {
+	results := make([]EnvironmentVariableBulkResult, len(envVars))
+	runBulk(ctx, len(envVars), opts, func(ctx context.Context, i int) error {
+		created, err := c.CreateEnvironmentVariable(ctx, envVars[i])
+		results[i] = EnvironmentVariableBulkResult{Index: i, Resource: created, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = EnvironmentVariableBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// UpdateEnvironmentVariableBulk updates environment variables
+// concurrently, bounded by opts.Concurrency, and reports one
+// EnvironmentVariableBulkResult per input update in the same order.
+func (c *client) UpdateEnvironmentVariableBulk(ctx context.Context, updates []EnvironmentVariableUpdate, opts BulkOptions) []EnvironmentVariableBulkResult {
+	results := make([]EnvironmentVariableBulkResult, len(updates))
+	runBulk(ctx, len(updates), opts, func(ctx context.Context, i int) error {
+		updated, err := c.UpdateEnvironmentVariable(ctx, updates[i].Key, updates[i].EnvironmentVariable)
+		results[i] = EnvironmentVariableBulkResult{Index: i, Resource: updated, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = EnvironmentVariableBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// DeleteEnvironmentVariableBulk deletes environment variables
+// concurrently, bounded by opts.Concurrency, and reports one
+// EnvironmentVariableBulkResult (with a nil Resource) per input key in
+// the same order.
+func (c *client) DeleteEnvironmentVariableBulk(ctx context.Context, keys []string, opts BulkOptions) []EnvironmentVariableBulkResult {
+	results := make([]EnvironmentVariableBulkResult, len(keys))
+	runBulk(ctx, len(keys), opts, func(ctx context.Context, i int) error {
+		err := c.DeleteEnvironmentVariable(ctx, keys[i])
+		results[i] = EnvironmentVariableBulkResult{Index: i, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = EnvironmentVariableBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// AlertChannelBulkResult is the per-item outcome of an AlertChannel bulk
+// operation.
+type AlertChannelBulkResult struct {
+	Index    int
+	Resource *AlertChannel
+	Err      error
+}
+
+// AlertChannelUpdate pairs an alert channel ID with the channel data to
+// write, for use with UpdateAlertChannelBulk.
+type AlertChannelUpdate struct {
+	ID           int64
+	AlertChannel AlertChannel
+}
+
+// CreateAlertChannelBulk creates alert channels concurrently, bounded by
+// opts.Concurrency, and reports one AlertChannelBulkResult per input
+// channel in the same order.
+func (c *client) CreateAlertChannelBulk(ctx context.Context, channels []AlertChannel, opts BulkOptions) []AlertChannelBulkResult {
+	results := make([]AlertChannelBulkResult, len(channels))
+	runBulk(ctx, len(channels), opts, func(ctx context.Context, i int) error {
+		created, err := c.CreateAlertChannel(ctx, channels[i])
+		results[i] = AlertChannelBulkResult{Index: i, Resource: created, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = AlertChannelBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// UpdateAlertChannelBulk updates alert channels concurrently, bounded by
+// opts.Concurrency, and reports one AlertChannelBulkResult per input
+// update in the same order.
+func (c *client) UpdateAlertChannelBulk(ctx context.Context, updates []AlertChannelUpdate, opts BulkOptions) []AlertChannelBulkResult {
+	results := make([]AlertChannelBulkResult, len(updates))
+	runBulk(ctx, len(updates), opts, func(ctx context.Context, i int) error {
+		updated, err := c.UpdateAlertChannel(ctx, updates[i].ID, updates[i].AlertChannel)
+		results[i] = AlertChannelBulkResult{Index: i, Resource: updated, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = AlertChannelBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
+
+// DeleteAlertChannelBulk deletes alert channels concurrently, bounded by
+// opts.Concurrency, and reports one AlertChannelBulkResult (with a nil
+// Resource) per input ID in the same order.
+func (c *client) DeleteAlertChannelBulk(ctx context.Context, ids []int64, opts BulkOptions) []AlertChannelBulkResult {
+	results := make([]AlertChannelBulkResult, len(ids))
+	runBulk(ctx, len(ids), opts, func(ctx context.Context, i int) error {
+		err := c.DeleteAlertChannel(ctx, ids[i])
+		results[i] = AlertChannelBulkResult{Index: i, Err: err}
+		return err
+	}, func(i int) {
+		results[i] = AlertChannelBulkResult{Index: i, Err: ErrBulkSkipped}
+	})
+	return results
+}
@@ -12,6 +12,10 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 func getEnv(key, fallback string) string {
@@ -21,8 +25,39 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
-// NewClient constructs a Checly API client.
-func NewClient(
+// NewClient constructs a Checkly API client, applying opts in order. The
+// zero-value client is not useful on its own: callers need at least
+// WithBaseURL and either WithAuthenticator or NewClientLegacy's apiKey
+// parameter to reach the API.
+//
+// This is a breaking change from the previous NewClient(baseURL, apiKey,
+// httpClient, debug) signature: Go has no overloading, so the old
+// positional constructor could not be kept under the same name. Existing
+// call sites must be updated to either NewClientLegacy (same arguments,
+// new name) or this functional-options form; there is no way to adopt
+// this release without touching every NewClient call site.
+func NewClient(opts ...Option) Client {
+	c := &client{
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewClientLegacy constructs a Checkly API client from positional
+// arguments, with the same signature NewClient used to have.
+//
+// Deprecated: use NewClient(opts ...Option) with WithBaseURL and
+// WithAuthenticator (or WithAuthenticator(StaticTokenAuth{Token:
+// apiKey})) instead. NewClientLegacy is kept for one release cycle to
+// ease the migration and will be removed afterwards. Note that the
+// rename itself is not a soft migration: any code calling the old
+// NewClient(baseURL, apiKey, httpClient, debug) will fail to compile
+// until it's updated to call NewClientLegacy (or NewClient with
+// options), since Go cannot keep two constructors with the same name.
+func NewClientLegacy(
 	//checkly API's base url
 	baseURL,
 	//checkly's api key
@@ -31,18 +66,12 @@ func NewClient(
 	httpClient *http.Client,
 	debug io.Writer,
 ) Client {
-	c := &client{
-		apiKey:     apiKey,
-		url:        baseURL,
-		httpClient: httpClient,
-		debug:      debug,
-	}
-	if httpClient != nil {
-		c.httpClient = httpClient
-	} else {
-		c.httpClient = http.DefaultClient
-	}
-	return c
+	return NewClient(
+		WithBaseURL(baseURL),
+		WithAuthenticator(StaticTokenAuth{Token: apiKey}),
+		WithHTTPClient(httpClient),
+		WithDebug(debug),
+	)
 }
 
 // Create creates a new check with the specified details. It returns the
@@ -65,7 +94,7 @@ func (c *client) Create(
 		return nil, err
 	}
 	if status != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodPost, withAutoAssignAlertsFlag("checks"), status, res)
 	}
 	var result Check
 	if err = json.NewDecoder(strings.NewReader(res)).Decode(&result); err != nil {
@@ -94,7 +123,7 @@ func (c *client) Update(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodPut, withAutoAssignAlertsFlag(fmt.Sprintf("checks/%s", ID)), status, res)
 	}
 	var result Check
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -120,7 +149,7 @@ func (c *client) Delete(
 		return err
 	}
 	if status != http.StatusNoContent {
-		return fmt.Errorf("unexpected response status %d: %q", status, res)
+		return newAPIError(http.MethodDelete, fmt.Sprintf("checks/%s", ID), status, res)
 	}
 	return nil
 }
@@ -141,7 +170,7 @@ func (c *client) Get(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodGet, fmt.Sprintf("checks/%s", ID), status, res)
 	}
 	result := Check{}
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -151,6 +180,32 @@ func (c *client) Get(
 	return &result, nil
 }
 
+// GetChecks returns the checks listed by a single GET of the checks
+// endpoint. It sends no page or limit parameters and does not request
+// further pages, so it holds only the checks on the API's first page.
+func (c *client) GetChecks(
+	ctx context.Context,
+) ([]Check, error) {
+	status, res, err := c.apiCall(
+		ctx,
+		http.MethodGet,
+		"checks",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, newAPIError(http.MethodGet, "checks", status, res)
+	}
+	result := []Check{}
+	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("decoding error for data %q: %v", res, err)
+	}
+	return result, nil
+}
+
 // CreateGroup creates a new check group with the specified details. It returns
 // the newly-created group, or an error.
 func (c *client) CreateGroup(
@@ -171,7 +226,7 @@ func (c *client) CreateGroup(
 		return nil, err
 	}
 	if status != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodPost, withAutoAssignAlertsFlag("check-groups"), status, res)
 	}
 	var result Group
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -197,7 +252,7 @@ func (c *client) GetGroup(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodGet, fmt.Sprintf("check-groups/%d", ID), status, res)
 	}
 	result := Group{}
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -207,6 +262,33 @@ func (c *client) GetGroup(
 	return &result, nil
 }
 
+// GetGroups returns the check groups listed by a single GET of the
+// check-groups endpoint. It sends no page or limit parameters and does
+// not request further pages, so it holds only the groups on the API's
+// first page.
+func (c *client) GetGroups(
+	ctx context.Context,
+) ([]Group, error) {
+	status, res, err := c.apiCall(
+		ctx,
+		http.MethodGet,
+		"check-groups",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, newAPIError(http.MethodGet, "check-groups", status, res)
+	}
+	result := []Group{}
+	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("decoding error for data %q: %v", res, err)
+	}
+	return result, nil
+}
+
 // UpdateGroup takes the ID of an existing check group, and updates the
 // corresponding check group to match the supplied group. It returns the updated
 // group, or an error.
@@ -229,7 +311,7 @@ func (c *client) UpdateGroup(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodPut, withAutoAssignAlertsFlag(fmt.Sprintf("check-groups/%d", ID)), status, res)
 	}
 	var result Group
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -255,7 +337,7 @@ func (c *client) DeleteGroup(
 		return err
 	}
 	if status != http.StatusNoContent {
-		return fmt.Errorf("unexpected response status %d: %q", status, res)
+		return newAPIError(http.MethodDelete, fmt.Sprintf("check-groups/%d", ID), status, res)
 	}
 	return nil
 }
@@ -276,7 +358,7 @@ func (c *client) GetCheckResult(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodGet, fmt.Sprintf("check-results/%s/%s", checkID, checkResultID), status, res)
 	}
 
 	result := CheckResult{}
@@ -331,7 +413,7 @@ func (c *client) GetCheckResults(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodGet, uri, status, res)
 	}
 	result := []CheckResult{}
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -356,7 +438,7 @@ func (c *client) CreateSnippet(
 		return nil, err
 	}
 	if status != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected response status: %d, res: %q", status, res)
+		return nil, newAPIError(http.MethodPost, "snippets", status, res)
 	}
 	var result Snippet
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -377,7 +459,7 @@ func (c *client) GetSnippet(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodGet, fmt.Sprintf("snippets/%d", ID), status, res)
 	}
 	result := Snippet{}
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -408,7 +490,7 @@ func (c *client) UpdateSnippet(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodPut, fmt.Sprintf("snippets/%d", ID), status, res)
 	}
 	var result Snippet
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -429,7 +511,7 @@ func (c *client) DeleteSnippet(
 		return err
 	}
 	if status != http.StatusNoContent {
-		return fmt.Errorf("unexpected response status %d: %q", status, res)
+		return newAPIError(http.MethodDelete, fmt.Sprintf("snippets/%d", ID), status, res)
 	}
 	return nil
 }
@@ -450,7 +532,7 @@ func (c *client) CreateEnvironmentVariable(
 		return nil, err
 	}
 	if status != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected response status: %d, res: %q", status, res)
+		return nil, newAPIError(http.MethodPost, "variables", status, res)
 	}
 	var result EnvironmentVariable
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -476,7 +558,7 @@ func (c *client) GetEnvironmentVariable(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodGet, fmt.Sprintf("variables/%s", key), status, res)
 	}
 	result := EnvironmentVariable{}
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -508,7 +590,7 @@ func (c *client) UpdateEnvironmentVariable(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodPut, fmt.Sprintf("variables/%s", key), status, res)
 	}
 	var result EnvironmentVariable
 	err = json.NewDecoder(strings.NewReader(res)).Decode(&result)
@@ -529,7 +611,7 @@ func (c *client) DeleteEnvironmentVariable(
 		return err
 	}
 	if status != http.StatusNoContent {
-		return fmt.Errorf("unexpected response status %d: %q", status, res)
+		return newAPIError(http.MethodDelete, fmt.Sprintf("variables/%s", key), status, res)
 	}
 	return nil
 }
@@ -550,7 +632,7 @@ func (c *client) CreateAlertChannel(
 		return nil, err
 	}
 	if status != http.StatusOK && status != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected response status: %d, res: %q, payload: %v", status, res, string(data))
+		return nil, newAPIError(http.MethodPost, "alert-channels", status, res)
 	}
 	return alertChannelFromJSON(res)
 }
@@ -566,7 +648,7 @@ func (c *client) GetAlertChannel(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodGet, fmt.Sprintf("alert-channels/%d", ID), status, res)
 	}
 	result := map[string]interface{}{}
 	if err = json.NewDecoder(strings.NewReader(res)).Decode(&result); err != nil {
@@ -593,7 +675,7 @@ func (c *client) UpdateAlertChannel(
 		return nil, err
 	}
 	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response status %d: %q", status, res)
+		return nil, newAPIError(http.MethodPut, fmt.Sprintf("alert-channels/%d", ID), status, res)
 	}
 	return alertChannelFromJSON(res)
 }
@@ -614,7 +696,7 @@ func (c *client) DeleteAlertChannel(
 		return err
 	}
 	if status != http.StatusNoContent {
-		return fmt.Errorf("unexpected response status %d: %q", status, res)
+		return newAPIError(http.MethodDelete, fmt.Sprintf("alert-channels/%d", ID), status, res)
 	}
 	return nil
 }
@@ -710,31 +792,122 @@ func (c *client) dumpResponse(resp *http.Response) {
 	fmt.Fprintln(c.debug)
 }
 
+// apiCall issues a single HTTP request, retrying transient failures
+// according to the client's RetryPolicy (DefaultRetryPolicy if unset).
 func (c *client) apiCall(
 	ctx context.Context,
 	method string,
 	URL string,
 	data []byte,
 ) (statusCode int, response string, err error) {
+	policy := c.retryPolicy
+	if override := retryPolicyOverride(ctx); override != nil {
+		policy = override
+	}
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	var lastResp *http.Response
+	if sink := warningsSink(ctx); sink != nil {
+		defer func() { *sink = append(*sink, extractWarnings(lastResp, response)...) }()
+	}
+	if sink := responseHeaderSink(ctx); sink != nil {
+		defer func() {
+			if lastResp != nil {
+				*sink = lastResp.Header
+			}
+		}()
+	}
+
+	var callRetries int
+	if c.instrumentation != nil {
+		var span trace.Span
+		ctx, span = c.instrumentation.startSpan(ctx, method, URL, c.url+"/v1/"+URL)
+		c.instrumentation.inFlight.Add(ctx, 1)
+		start := time.Now()
+		defer func() {
+			c.instrumentation.inFlight.Add(ctx, -1)
+			c.instrumentation.finish(ctx, span, method, URL, statusCode, callRetries, time.Since(start), err)
+		}()
+	}
+
+	deadline := time.Now().Add(policy.Budget)
+	for attempt := 1; ; attempt++ {
+		var resp *http.Response
+		statusCode, response, resp, err = c.apiCallOnce(ctx, method, URL, data)
+		lastResp = resp
+		connErr := resp == nil && err != nil
+		if connErr && ctx.Err() != nil {
+			return statusCode, response, err
+		}
+
+		if !policy.shouldRetry(attempt, method, statusCode, connErr, err) {
+			return statusCode, response, err
+		}
+
+		wait := policy.backoff(attempt)
+		if d, ok := retryAfter(resp); ok && (method == http.MethodPost && statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable) {
+			wait = d
+		}
+		if policy.Budget > 0 && time.Now().Add(wait).After(deadline) {
+			return statusCode, response, err
+		}
+		atomic.AddInt64(&c.retries, 1)
+		callRetries++
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, resp, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return statusCode, response, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// apiCallOnce performs a single attempt of an HTTP request, with no
+// retry logic. It returns the raw *http.Response (body already drained
+// into response) so callers can inspect headers such as Retry-After.
+func (c *client) apiCallOnce(
+	ctx context.Context,
+	method string,
+	URL string,
+	data []byte,
+) (statusCode int, response string, resp *http.Response, err error) {
 	requestURL := c.url + "/v1/" + URL
-	req, err := http.NewRequest(method, requestURL, bytes.NewBuffer(data))
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(data))
 	if err != nil {
-		return 0, "", fmt.Errorf("failed to create HTTP request: %v", err)
+		return 0, "", nil, fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+	if c.authenticator != nil {
+		if err := c.authenticator.AuthenticateRequest(req); err != nil {
+			return 0, "", nil, fmt.Errorf("authenticating request: %w", err)
+		}
 	}
-	req.Header.Add("Authorization", "Bearer "+c.apiKey)
 	req.Header.Add("content-type", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.accountID != "" {
+		req.Header.Set("X-Checkly-Account", c.accountID)
+	}
+	if c.instrumentation != nil {
+		c.instrumentation.inject(ctx, req)
+	}
 	if c.debug != nil {
 		requestDump, err := httputil.DumpRequestOut(req, true)
 		if err != nil {
-			return 0, "", fmt.Errorf("error dumping HTTP request: %v", err)
+			return 0, "", nil, fmt.Errorf("error dumping HTTP request: %v", err)
 		}
 		fmt.Fprintln(c.debug, string(requestDump))
 		fmt.Fprintln(c.debug)
 	}
 	req = req.WithContext(ctx)
-	resp, err := c.httpClient.Do(req)
+	resp, err = c.httpClient.Do(req)
 	if err != nil {
-		return 0, "", fmt.Errorf("HTTP request failed with: %v", err)
+		return 0, "", nil, fmt.Errorf("HTTP request failed with: %v", err)
 	}
 	defer resp.Body.Close()
 	if c.debug != nil {
@@ -742,9 +915,21 @@ func (c *client) apiCall(
 	}
 	res, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return resp.StatusCode, "", fmt.Errorf("HTTP request failed: %v", err)
+		return resp.StatusCode, "", resp, fmt.Errorf("HTTP request failed: %v", err)
 	}
-	return resp.StatusCode, string(res), nil
+	return resp.StatusCode, string(res), resp, nil
+}
+
+// Retries returns the total number of retry attempts the client has made
+// across all apiCall invocations so far.
+func (c *client) Retries() int64 {
+	return atomic.LoadInt64(&c.retries)
+}
+
+// SetRetryPolicy overrides the RetryPolicy used by apiCall. Passing nil
+// restores DefaultRetryPolicy.
+func (c *client) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
 }
 
 func withAutoAssignAlertsFlag(url string) string {
@@ -0,0 +1,84 @@
+package checkly
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned by client methods when the Checkly API responds
+// with an unexpected status code. It carries enough structure for callers
+// to branch on failure classes with errors.Is/errors.As instead of
+// string-matching Error().
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	RawBody    string
+	Endpoint   string
+	Method     string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("checkly: %s %s: %d %s: %s", e.Method, e.Endpoint, e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("checkly: %s %s: unexpected response status %d: %q", e.Method, e.Endpoint, e.StatusCode, e.RawBody)
+}
+
+// Is implements the errors.Is interface, allowing callers to test
+// *APIError values against the sentinel errors below without depending on
+// the exact status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	default:
+		return false
+	}
+}
+
+// Sentinel errors for the failure classes callers most commonly need to
+// branch on. Use errors.Is(err, checkly.ErrNotFound) rather than
+// inspecting err.Error().
+var (
+	ErrNotFound     = errors.New("checkly: not found")
+	ErrUnauthorized = errors.New("checkly: unauthorized")
+	ErrRateLimited  = errors.New("checkly: rate limited")
+	ErrValidation   = errors.New("checkly: validation failed")
+)
+
+// apiErrorBody is the subset of the Checkly error response shape we
+// understand. Fields are best-effort: endpoints that don't return a JSON
+// error body still produce a usable APIError with RawBody set.
+type apiErrorBody struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
+}
+
+// newAPIError builds an *APIError from a non-2xx response. body is the
+// raw response payload as returned by apiCall.
+func newAPIError(method, endpoint string, statusCode int, body string) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		RawBody:    body,
+		Endpoint:   endpoint,
+		Method:     method,
+	}
+	var parsed apiErrorBody
+	if json.Unmarshal([]byte(body), &parsed) == nil {
+		apiErr.Code = parsed.Code
+		apiErr.Message = parsed.Message
+		apiErr.RequestID = parsed.RequestID
+	}
+	return apiErr
+}
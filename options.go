@@ -0,0 +1,77 @@
+package checkly
+
+import (
+	"io"
+	"net/http"
+)
+
+// Option configures a client constructed by NewClient.
+type Option func(*client)
+
+// WithBaseURL sets the Checkly API's base URL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *client) {
+		c.url = baseURL
+	}
+}
+
+// WithHTTPClient sets the *http.Client used to make requests. A nil
+// httpClient is a no-op, leaving the client's default (http.DefaultClient)
+// in place.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithDebug sets the writer that request/response dumps are written to.
+// A nil writer is a no-op; omit this option to disable debug output.
+func WithDebug(w io.Writer) Option {
+	return func(c *client) {
+		if w != nil {
+			c.debug = w
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithAccountID sets the X-Checkly-Account header required by endpoints
+// that operate across accounts.
+func WithAccountID(accountID string) Option {
+	return func(c *client) {
+		c.accountID = accountID
+	}
+}
+
+// WithAuthenticator sets the Authenticator used to sign outgoing
+// requests. See StaticTokenAuth, EnvTokenAuth and RefreshingTokenAuth for
+// built-in implementations.
+func WithAuthenticator(a Authenticator) Option {
+	return func(c *client) {
+		c.authenticator = a
+	}
+}
+
+// WithRetryPolicy sets the RetryPolicy apiCall uses. Passing nil restores
+// DefaultRetryPolicy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithInstrumentation attaches OpenTelemetry tracing and metrics to the
+// client. See NewInstrumentation.
+func WithInstrumentation(i *Instrumentation) Option {
+	return func(c *client) {
+		c.instrumentation = i
+	}
+}
@@ -0,0 +1,141 @@
+package checkly
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is used as both the OpenTelemetry tracer and meter
+// name, so spans/metrics emitted by this SDK are easy to filter on.
+const instrumentationName = "github.com/devillexio/checkly-go-sdk"
+
+// Instrumentation wires OpenTelemetry tracing and metrics into apiCall.
+// Construct one with NewInstrumentation and attach it to a client via
+// SetInstrumentation (or, with the functional-options constructor,
+// WithInstrumentation). An Instrumentation built as a bare struct literal
+// (TracerProvider/MeterProvider set, nothing else) still works: its
+// signals are lazily derived from those providers on first use.
+type Instrumentation struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+
+	initOnce      sync.Once
+	initErr       error
+	tracer        trace.Tracer
+	propagator    propagation.TextMapPropagator
+	requestTotal  metric.Int64Counter
+	latency       metric.Float64Histogram
+	inFlight      metric.Int64UpDownCounter
+}
+
+// NewInstrumentation builds an Instrumentation from the given providers.
+// Either may be nil, in which case OpenTelemetry's no-op implementations
+// are used for that signal.
+func NewInstrumentation(tp trace.TracerProvider, mp metric.MeterProvider) (*Instrumentation, error) {
+	i := &Instrumentation{TracerProvider: tp, MeterProvider: mp}
+	if err := i.ensureInitialized(); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// ensureInitialized derives the tracer/meter instruments from
+// TracerProvider/MeterProvider the first time it's called, so an
+// Instrumentation built directly (rather than via NewInstrumentation) is
+// still safe to use instead of panicking on a nil tracer.
+func (i *Instrumentation) ensureInitialized() error {
+	i.initOnce.Do(func() {
+		tp := i.TracerProvider
+		if tp == nil {
+			tp = trace.NewNoopTracerProvider()
+		}
+		mp := i.MeterProvider
+		if mp == nil {
+			mp = metric.NewNoopMeterProvider()
+		}
+
+		i.tracer = tp.Tracer(instrumentationName)
+		i.propagator = propagation.TraceContext{}
+
+		meter := mp.Meter(instrumentationName)
+		i.requestTotal, i.initErr = meter.Int64Counter(
+			"checkly.client.requests",
+			metric.WithDescription("Total number of Checkly API requests, by endpoint and status"),
+		)
+		if i.initErr != nil {
+			return
+		}
+		i.latency, i.initErr = meter.Float64Histogram(
+			"checkly.client.request.duration",
+			metric.WithDescription("Checkly API request latency in seconds"),
+			metric.WithUnit("s"),
+		)
+		if i.initErr != nil {
+			return
+		}
+		i.inFlight, i.initErr = meter.Int64UpDownCounter(
+			"checkly.client.requests.in_flight",
+			metric.WithDescription("Number of in-flight Checkly API requests"),
+		)
+	})
+	return i.initErr
+}
+
+// SetInstrumentation attaches OpenTelemetry tracing and metrics to the
+// client. Passing nil disables instrumentation.
+func (c *client) SetInstrumentation(i *Instrumentation) {
+	c.instrumentation = i
+}
+
+// inject adds a W3C traceparent header carrying ctx's span to req, so
+// operators tracing a service that calls into this SDK can correlate the
+// two.
+func (i *Instrumentation) inject(ctx context.Context, req *http.Request) {
+	i.ensureInitialized()
+	i.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// startSpan begins a span for a logical apiCall (which may itself retry
+// several times), returning the derived context to use for the
+// underlying HTTP requests. url is the full request URL, including the
+// client's base URL and API version prefix.
+func (i *Instrumentation) startSpan(ctx context.Context, method, endpoint, url string) (context.Context, trace.Span) {
+	i.ensureInitialized()
+	return i.tracer.Start(ctx, "checkly.apiCall", trace.WithAttributes(
+		attribute.String("http.method", method),
+		attribute.String("http.url", url),
+		attribute.String("checkly.endpoint", endpoint),
+	))
+}
+
+// finish records the outcome of a logical apiCall: span attributes/status
+// and the request-total and latency metrics. The in-flight counter is
+// adjusted by apiCall itself.
+func (i *Instrumentation) finish(ctx context.Context, span trace.Span, method, endpoint string, statusCode, retries int, duration time.Duration, err error) {
+	i.ensureInitialized()
+	span.SetAttributes(
+		attribute.Int("http.status_code", statusCode),
+		attribute.Int("checkly.retry.count", retries),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	attrs := metric.WithAttributes(
+		attribute.String("checkly.endpoint", endpoint),
+		attribute.String("http.method", method),
+		attribute.Int("http.status_code", statusCode),
+	)
+	i.requestTotal.Add(ctx, 1, attrs)
+	i.latency.Record(ctx, duration.Seconds(), attrs)
+}
@@ -0,0 +1,217 @@
+package checkly
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// checkResultsPrefetchBuffer bounds how many results the iterator will
+// buffer ahead of the caller while fetching the next page.
+const checkResultsPrefetchBuffer = 50
+
+// defaultCheckResultsPageSize is used when the caller's filter doesn't
+// set a Limit, so the iterator knows how many results constitute a full
+// page when deciding whether to fetch again.
+const defaultCheckResultsPageSize = 100
+
+// CheckResultsIterator walks all pages of GetCheckResults transparently,
+// prefetching the next page in the background while the current one is
+// being consumed by the caller.
+type CheckResultsIterator struct {
+	cancel context.CancelFunc
+
+	results chan CheckResult
+	errc    chan error
+	done    chan struct{}
+
+	cur CheckResult
+	err error
+}
+
+// NewCheckResultsIterator returns a CheckResultsIterator over all pages of
+// check results for checkID matching filter. filter may be nil. The
+// iterator owns a derived context and stops fetching as soon as ctx is
+// done or Close is called.
+func (c *client) NewCheckResultsIterator(
+	ctx context.Context,
+	checkID string,
+	filter *CheckResultsFilter,
+) *CheckResultsIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	it := &CheckResultsIterator{
+		cancel:  cancel,
+		results: make(chan CheckResult, checkResultsPrefetchBuffer),
+		errc:    make(chan error, 1),
+		done:    make(chan struct{}),
+	}
+	go it.fetchAll(ctx, c, checkID, filter)
+	return it
+}
+
+func (it *CheckResultsIterator) fetchAll(
+	ctx context.Context,
+	c *client,
+	checkID string,
+	filter *CheckResultsFilter,
+) {
+	defer close(it.results)
+	defer close(it.done)
+
+	pageSize := defaultCheckResultsPageSize
+	pageFilter := CheckResultsFilter{}
+	if filter != nil {
+		pageFilter = *filter
+		if pageFilter.Limit > 0 {
+			pageSize = pageFilter.Limit
+		}
+	}
+	pageFilter.Limit = pageSize
+	if pageFilter.Page < 1 {
+		pageFilter.Page = 1
+	}
+
+	usesLinkPagination := false
+	for {
+		var headers http.Header
+		page, err := c.GetCheckResults(withResponseHeader(ctx, &headers), checkID, &pageFilter)
+		if err != nil {
+			select {
+			case it.errc <- err:
+			default:
+			}
+			return
+		}
+		for _, r := range page {
+			select {
+			case it.results <- r:
+			case <-ctx.Done():
+				select {
+				case it.errc <- ctx.Err():
+				default:
+				}
+				return
+			}
+		}
+		if nextPage, ok := nextPageFromLinkHeader(headers); ok {
+			usesLinkPagination = true
+			if nextPage <= pageFilter.Page {
+				return
+			}
+			pageFilter.Page = nextPage
+			continue
+		}
+		// Once the API has shown it sends a Link header on non-final
+		// pages, a page without one is the end of the listing, even if
+		// it happens to be a full page — falling through to the
+		// length-based heuristic below would re-request forever.
+		if usesLinkPagination {
+			return
+		}
+		if len(page) < pageSize {
+			return
+		}
+		pageFilter.Page++
+	}
+}
+
+// linkNextPattern matches the "next" entry of an RFC 5988 Link header,
+// e.g. `<https://api.checklyhq.com/v1/check-results/abc?page=2>; rel="next"`.
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// nextPageFromLinkHeader extracts the "page" query parameter from a
+// Link: rel="next" header, when the API sends one. It returns false if
+// there's no such header, or it doesn't carry a numeric page parameter
+// our own CheckResultsFilter.Page can use.
+func nextPageFromLinkHeader(headers http.Header) (int, bool) {
+	if headers == nil {
+		return 0, false
+	}
+	match := linkNextPattern.FindStringSubmatch(headers.Get("Link"))
+	if match == nil {
+		return 0, false
+	}
+	u, err := url.Parse(match[1])
+	if err != nil {
+		return 0, false
+	}
+	page, err := strconv.Atoi(u.Query().Get("page"))
+	if err != nil {
+		return 0, false
+	}
+	return page, true
+}
+
+// Next advances the iterator and reports whether a result is available
+// via Result. It returns false when iteration is done, either because
+// all pages were consumed, ctx was cancelled, Close was called, or an
+// error occurred (check Err to tell these apart).
+func (it *CheckResultsIterator) Next(ctx context.Context) bool {
+	select {
+	case r, ok := <-it.results:
+		if !ok {
+			select {
+			case it.err = <-it.errc:
+			default:
+			}
+			return false
+		}
+		it.cur = r
+		return true
+	case <-ctx.Done():
+		it.err = ctx.Err()
+		return false
+	}
+}
+
+// Result returns the CheckResult produced by the most recent call to
+// Next.
+func (it *CheckResultsIterator) Result() CheckResult {
+	return it.cur
+}
+
+// Err returns the error, if any, that stopped iteration. It is nil if
+// iteration stopped because all pages were exhausted.
+func (it *CheckResultsIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iterator's background fetching. It is safe to call
+// multiple times and safe to call before iteration is complete.
+func (it *CheckResultsIterator) Close() {
+	it.cancel()
+	<-it.done
+}
+
+// StreamCheckResults is a channel-based alternative to
+// CheckResultsIterator for pipeline-style consumers. The returned
+// channels are both closed when iteration completes; at most one value
+// is ever sent on the error channel. Consumers should range over the
+// result channel and then check the error channel for a final error.
+func (c *client) StreamCheckResults(
+	ctx context.Context,
+	checkID string,
+	filter *CheckResultsFilter,
+) (<-chan CheckResult, <-chan error) {
+	it := c.NewCheckResultsIterator(ctx, checkID, filter)
+	out := make(chan CheckResult)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(out)
+		defer close(errc)
+		for it.Next(ctx) {
+			select {
+			case out <- it.Result():
+			case <-ctx.Done():
+				it.Close()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errc <- err
+		}
+	}()
+	return out, errc
+}
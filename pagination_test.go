@@ -0,0 +1,139 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func newPaginationTestClient(t *testing.T, url string) *client {
+	t.Helper()
+	c := NewClient(
+		WithBaseURL(url),
+		WithAuthenticator(StaticTokenAuth{Token: "test"}),
+		WithRetryPolicy(NoRetries()),
+	)
+	return c.(*client)
+}
+
+// checkResultsPageServer serves totalPages pages of 2 results each via
+// the check-results/{id} endpoint, advertising each non-final page via a
+// Link: rel="next" header. The request for page failOnPage gets a 500
+// (0 disables this).
+func checkResultsPageServer(t *testing.T, totalPages int, failOnPage int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			fmt.Sscanf(p, "%d", &page)
+		}
+		if page == failOnPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"boom"}`))
+			return
+		}
+		if page < totalPages {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d>; rel="next"`, r.URL.Path, page+1))
+		}
+		results := []CheckResult{
+			{ID: fmt.Sprintf("p%d-1", page)},
+			{ID: fmt.Sprintf("p%d-2", page)},
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(results)
+	}))
+}
+
+func TestCheckResultsIteratorOrdersAcrossPages(t *testing.T) {
+	server := checkResultsPageServer(t, 3, 0)
+	defer server.Close()
+
+	c := newPaginationTestClient(t, server.URL)
+	it := c.NewCheckResultsIterator(context.Background(), "check-1", &CheckResultsFilter{Limit: 2})
+	defer it.Close()
+
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Result().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"p1-1", "p1-2", "p2-1", "p2-2", "p3-1", "p3-2"}
+	if len(ids) != len(want) {
+		t.Fatalf("got %d results, want %d: %v", len(ids), len(want), ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("result %d = %q, want %q (full: %v)", i, ids[i], want[i], ids)
+		}
+	}
+}
+
+func TestCheckResultsIteratorPropagatesMidStreamError(t *testing.T) {
+	server := checkResultsPageServer(t, 3, 2)
+	defer server.Close()
+
+	c := newPaginationTestClient(t, server.URL)
+	it := c.NewCheckResultsIterator(context.Background(), "check-1", &CheckResultsFilter{Limit: 2})
+	defer it.Close()
+
+	var count int
+	for it.Next(context.Background()) {
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("got %d results before the error, want 2 (only page 1)", count)
+	}
+	if it.Err() == nil {
+		t.Fatal("expected an error propagated from the failing page, got nil")
+	}
+}
+
+func TestStreamCheckResultsPropagatesError(t *testing.T) {
+	server := checkResultsPageServer(t, 2, 1)
+	defer server.Close()
+
+	c := newPaginationTestClient(t, server.URL)
+	results, errc := c.StreamCheckResults(context.Background(), "check-1", &CheckResultsFilter{Limit: 2})
+
+	for range results {
+	}
+	select {
+	case err := <-errc:
+		if err == nil {
+			t.Fatal("expected a non-nil error on the error channel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for error channel")
+	}
+}
+
+func TestCheckResultsIteratorCloseDoesNotLeakGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	server := checkResultsPageServer(t, 1000, 0)
+	defer server.Close()
+
+	c := newPaginationTestClient(t, server.URL)
+	it := c.NewCheckResultsIterator(context.Background(), "check-1", &CheckResultsFilter{Limit: 2})
+
+	// Consume one result, then close without draining the rest;
+	// Close must block until the background fetch goroutine has exited.
+	it.Next(context.Background())
+	it.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before+2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := runtime.NumGoroutine(); got > before+2 {
+		t.Fatalf("goroutine count %d did not settle back near baseline %d after Close", got, before)
+	}
+}
@@ -0,0 +1,22 @@
+package checkly
+
+import (
+	"context"
+	"net/http"
+)
+
+type responseHeaderContextKey struct{}
+
+// withResponseHeader returns a context that causes apiCall to copy the
+// headers of the (final, post-retry) HTTP response into header. This is
+// used internally by things like CheckResultsIterator that need to
+// inspect headers such as Link without apiCall's public methods having
+// to grow a header-returning variant.
+func withResponseHeader(ctx context.Context, header *http.Header) context.Context {
+	return context.WithValue(ctx, responseHeaderContextKey{}, header)
+}
+
+func responseHeaderSink(ctx context.Context) *http.Header {
+	sink, _ := ctx.Value(responseHeaderContextKey{}).(*http.Header)
+	return sink
+}
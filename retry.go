@@ -0,0 +1,164 @@
+package checkly
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries transient failures from
+// apiCall. A nil *RetryPolicy on the client falls back to
+// DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retry attempts after the initial
+	// request. A value of 0 disables retries.
+	MaxRetries int
+	// Budget bounds the total wall-clock time spent across all attempts of
+	// a single apiCall, including waits between attempts. It is not a
+	// per-attempt timeout; use the context for that.
+	Budget time.Duration
+	// BaseDelay and MaxDelay bound the exponential backoff computed between
+	// attempts, before jitter is applied.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// RetryPOST allows retrying POST requests that were not already
+	// eligible under the "no state created" rule (e.g. a 503 response with
+	// a body). POST is never retried after a response has been received
+	// unless this is set, since the server may have already applied the
+	// write.
+	RetryPOST bool
+	// OnRetry, if set, is called once for each retry, after the failed
+	// attempt that triggers it and before the backoff sleep. It is not
+	// called for a failed attempt that is not retried. resp is nil when the
+	// attempt failed before a response was received (e.g. a network error).
+	OnRetry func(attempt int, resp *http.Response, err error)
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used when a client has none
+// configured: up to 5 retries, a 30s total budget, and a backoff ceiling
+// that starts at 200ms and is capped at 10s (each wait is drawn at random
+// between zero and that ceiling).
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: 5,
+		Budget:     30 * time.Second,
+		BaseDelay:  200 * time.Millisecond,
+		MaxDelay:   10 * time.Second,
+	}
+}
+
+// NoRetries is a RetryPolicy that never retries, useful for callers that
+// want the pre-retry apiCall behavior.
+func NoRetries() *RetryPolicy {
+	return &RetryPolicy{MaxRetries: 0}
+}
+
+type retryPolicyContextKey struct{}
+
+// withRetryPolicyOverride returns a context that causes apiCall to use
+// policy for the duration of calls made with it, in preference to the
+// client's own RetryPolicy. This lets callers like the bulk operations
+// scope a RetryPolicy to a single call tree without mutating shared
+// client state.
+func withRetryPolicyOverride(ctx context.Context, policy *RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyContextKey{}, policy)
+}
+
+func retryPolicyOverride(ctx context.Context) *RetryPolicy {
+	policy, _ := ctx.Value(retryPolicyContextKey{}).(*RetryPolicy)
+	return policy
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodHead:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryableStatus reports whether statusCode alone is reason enough to
+// retry, regardless of method.
+func retryableStatus(statusCode int) bool {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return true
+	case statusCode == http.StatusServiceUnavailable:
+		return true
+	case statusCode >= 500:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry decides whether attempt (1-indexed) should be retried given
+// the outcome of the request. connErr indicates the failure happened
+// before any response was received, which makes POST safe to retry even
+// without RetryPOST since no state could have been created server-side.
+func (p *RetryPolicy) shouldRetry(attempt int, method string, statusCode int, connErr bool, err error) bool {
+	if attempt > p.MaxRetries {
+		return false
+	}
+	if err != nil && !connErr {
+		return false
+	}
+	if connErr {
+		return isIdempotentMethod(method) || method == http.MethodPost
+	}
+	if !retryableStatus(statusCode) {
+		return false
+	}
+	if method == http.MethodPost {
+		return p.RetryPOST
+	}
+	return true
+}
+
+// backoff computes the exponential backoff with full jitter for the given
+// attempt (1-indexed), per https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = DefaultRetryPolicy().BaseDelay
+	}
+	max := p.MaxDelay
+	if max <= 0 {
+		max = DefaultRetryPolicy().MaxDelay
+	}
+	cap := float64(base) * float64(int64(1)<<uint(attempt-1))
+	if cap > float64(max) || cap <= 0 {
+		cap = float64(max)
+	}
+	return time.Duration(rand.Int63n(int64(cap) + 1))
+}
+
+// retryAfter parses a Retry-After header, which per RFC 7231 is either a
+// number of seconds or an HTTP-date. It returns false if the header is
+// absent or unparseable.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(v); err == nil {
+		d := time.Until(when)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
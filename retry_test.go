@@ -0,0 +1,214 @@
+package checkly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: 5,
+		Budget:     time.Second,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+	}
+}
+
+func newTestClient(t *testing.T, url string, policy *RetryPolicy) *client {
+	t.Helper()
+	c := NewClient(
+		WithBaseURL(url),
+		WithAuthenticator(StaticTokenAuth{Token: "test"}),
+		WithRetryPolicy(policy),
+	)
+	return c.(*client)
+}
+
+func TestApiCallRetriesOn503WithRetryAfterSeconds(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, fastRetryPolicy())
+	status, _, err := c.apiCall(context.Background(), http.MethodGet, "checks/abc", nil)
+	if err != nil {
+		t.Fatalf("apiCall returned error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d", status, http.StatusOK)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("got %d attempts, want 3", got)
+	}
+	if got := c.Retries(); got != 2 {
+		t.Fatalf("Retries() = %d, want 2", got)
+	}
+}
+
+func TestApiCallRetryAfterHTTPDate(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 2 {
+			// HTTP-dates have one-second resolution, so a date 2s ahead
+			// still lies at least 1s in the future once truncated.
+			w.Header().Set("Retry-After", time.Now().Add(2*time.Second).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	policy := fastRetryPolicy()
+	policy.Budget = 5 * time.Second
+	c := newTestClient(t, server.URL, policy)
+	start := time.Now()
+	status, _, err := c.apiCall(context.Background(), http.MethodGet, "checks/abc", nil)
+	if err != nil {
+		t.Fatalf("apiCall returned error: %v", err)
+	}
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d", status, http.StatusOK)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("apiCall returned after %v, expected to honor Retry-After date (>= 1s)", elapsed)
+	}
+}
+
+func TestApiCallBudgetCapsTotalWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{MaxRetries: 5, Budget: 20 * time.Millisecond, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	c := newTestClient(t, server.URL, policy)
+
+	start := time.Now()
+	status, _, err := c.apiCall(context.Background(), http.MethodGet, "checks/abc", nil)
+	if err != nil {
+		t.Fatalf("apiCall returned error: %v", err)
+	}
+	if status != http.StatusServiceUnavailable {
+		t.Fatalf("got status %d, want %d", status, http.StatusServiceUnavailable)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("apiCall took %v, budget of 20ms should have aborted the 3600s Retry-After wait", elapsed)
+	}
+}
+
+func TestApiCallPOSTNotRetriedByDefault(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, fastRetryPolicy())
+	status, _, err := c.apiCall(context.Background(), http.MethodPost, "checks", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("apiCall returned error: %v", err)
+	}
+	if status != http.StatusServiceUnavailable {
+		t.Fatalf("got status %d, want %d", status, http.StatusServiceUnavailable)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("got %d attempts, want 1 (POST should not retry by default)", got)
+	}
+}
+
+func TestApiCallPOSTRetriesWhenOptedIn(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	policy := fastRetryPolicy()
+	policy.RetryPOST = true
+	c := newTestClient(t, server.URL, policy)
+
+	status, _, err := c.apiCall(context.Background(), http.MethodPost, "checks", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("apiCall returned error: %v", err)
+	}
+	if status != http.StatusCreated {
+		t.Fatalf("got status %d, want %d", status, http.StatusCreated)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("got %d attempts, want 2", got)
+	}
+}
+
+func TestApiCallHonorsContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{MaxRetries: 100, Budget: time.Minute, BaseDelay: 50 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	c := newTestClient(t, server.URL, policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := c.apiCall(ctx, http.MethodGet, "checks/abc", nil)
+	if err == nil {
+		t.Fatal("expected an error from a cancelled context")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("apiCall took %v after context cancellation, want it to return promptly", elapsed)
+	}
+}
+
+func TestApiCallOnRetryHookFires(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var hookCalls int32
+	policy := fastRetryPolicy()
+	policy.OnRetry = func(attempt int, resp *http.Response, err error) {
+		atomic.AddInt32(&hookCalls, 1)
+	}
+	c := newTestClient(t, server.URL, policy)
+
+	if _, _, err := c.apiCall(context.Background(), http.MethodGet, "checks/abc", nil); err != nil {
+		t.Fatalf("apiCall returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&hookCalls); got != 1 {
+		t.Fatalf("OnRetry called %d times, want 1", got)
+	}
+}
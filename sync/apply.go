@@ -0,0 +1,158 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	checkly "github.com/devillexio/checkly-go-sdk"
+)
+
+// Applier is the subset of checkly.Client that Apply needs to execute a
+// Plan's operations.
+type Applier interface {
+	Create(ctx context.Context, check checkly.Check) (*checkly.Check, error)
+	Update(ctx context.Context, ID string, check checkly.Check) (*checkly.Check, error)
+	Delete(ctx context.Context, ID string) error
+	CreateGroup(ctx context.Context, group checkly.Group) (*checkly.Group, error)
+	UpdateGroup(ctx context.Context, ID int64, group checkly.Group) (*checkly.Group, error)
+	DeleteGroup(ctx context.Context, ID int64) error
+}
+
+// ApplyOptions controls how Apply executes a Plan.
+type ApplyOptions struct {
+	// DryRun reports what would happen without calling the API.
+	DryRun bool
+	// Rollback reverses already-applied operations, in reverse order,
+	// when a later operation in the same Apply call fails.
+	Rollback bool
+}
+
+// Result is the outcome of executing a single Operation.
+type Result struct {
+	Operation Operation
+	// CreatedID is the server-assigned ID of a resource created by this
+	// operation (Check.ID, or the string form of Group.ID). It is only
+	// set for successful OpCreate operations, and is what Apply uses to
+	// reverse a create during rollback.
+	CreatedID string
+	Err       error
+}
+
+// Report summarizes the outcome of an Apply call.
+type Report struct {
+	Results    []Result
+	RolledBack []Result
+}
+
+// Apply executes plan's operations in order against client. On the first
+// failure it stops, and if opts.Rollback is set, reverses the operations
+// that had already succeeded, in reverse order, on a best-effort basis.
+func Apply(ctx context.Context, client Applier, plan *Plan, opts ApplyOptions) (*Report, error) {
+	report := &Report{}
+	for _, op := range plan.Operations {
+		if op.Op == OpNoOp {
+			report.Results = append(report.Results, Result{Operation: op})
+			continue
+		}
+		if opts.DryRun {
+			report.Results = append(report.Results, Result{Operation: op})
+			continue
+		}
+
+		createdID, err := execute(ctx, client, op)
+		if err != nil {
+			report.Results = append(report.Results, Result{Operation: op, Err: err})
+			if opts.Rollback {
+				report.RolledBack = rollback(ctx, client, report.Results)
+			}
+			return report, fmt.Errorf("sync: applying %s %s %q: %w", op.Op, op.Kind, op.Name, err)
+		}
+		report.Results = append(report.Results, Result{Operation: op, CreatedID: createdID})
+	}
+	return report, nil
+}
+
+func execute(ctx context.Context, client Applier, op Operation) (createdID string, err error) {
+	switch op.Kind {
+	case KindCheck:
+		return executeCheck(ctx, client, op)
+	case KindGroup:
+		return executeGroup(ctx, client, op)
+	default:
+		return "", fmt.Errorf("sync: unknown resource kind %q", op.Kind)
+	}
+}
+
+func executeCheck(ctx context.Context, client Applier, op Operation) (string, error) {
+	switch op.Op {
+	case OpCreate:
+		created, err := client.Create(ctx, *op.Check)
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	case OpUpdate:
+		_, err := client.Update(ctx, op.ID, *op.Check)
+		return "", err
+	case OpDelete:
+		return "", client.Delete(ctx, op.ID)
+	default:
+		return "", nil
+	}
+}
+
+func executeGroup(ctx context.Context, client Applier, op Operation) (string, error) {
+	if op.Op == OpCreate {
+		created, err := client.CreateGroup(ctx, *op.Group)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(created.ID, 10), nil
+	}
+
+	id, err := strconv.ParseInt(op.ID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("sync: invalid group ID %q: %w", op.ID, err)
+	}
+	switch op.Op {
+	case OpUpdate:
+		_, err := client.UpdateGroup(ctx, id, *op.Group)
+		return "", err
+	case OpDelete:
+		return "", client.DeleteGroup(ctx, id)
+	default:
+		return "", nil
+	}
+}
+
+// rollback reverses the successful results, in reverse order, on a
+// best-effort basis: a Create is undone with a Delete, a Delete cannot
+// be undone and is skipped, and an Update is left as-is since the prior
+// state is not retained.
+func rollback(ctx context.Context, client Applier, results []Result) []Result {
+	var rolledBack []Result
+	for i := len(results) - 1; i >= 0; i-- {
+		r := results[i]
+		if r.Err != nil || r.Operation.Op != OpCreate {
+			continue
+		}
+		if r.CreatedID == "" {
+			continue
+		}
+		var err error
+		switch r.Operation.Kind {
+		case KindCheck:
+			err = client.Delete(ctx, r.CreatedID)
+		case KindGroup:
+			id, parseErr := strconv.ParseInt(r.CreatedID, 10, 64)
+			if parseErr != nil {
+				err = parseErr
+				break
+			}
+			err = client.DeleteGroup(ctx, id)
+		}
+		rolledBack = append(rolledBack, Result{Operation: r.Operation, Err: err})
+	}
+	return rolledBack
+}
@@ -0,0 +1,281 @@
+// Package sync implements a declarative, terraform-like reconciliation
+// loop on top of the checkly package's CRUD methods: callers describe the
+// checks and groups they want to exist, NewPlan diffs that against what the
+// account currently has, and Apply executes the resulting operations.
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	checkly "github.com/devillexio/checkly-go-sdk"
+)
+
+// OperationType describes what a single Operation does.
+type OperationType string
+
+const (
+	OpCreate OperationType = "create"
+	OpUpdate OperationType = "update"
+	OpDelete OperationType = "delete"
+	OpNoOp   OperationType = "noop"
+)
+
+// ResourceKind identifies the kind of resource an Operation acts on.
+type ResourceKind string
+
+const (
+	KindCheck ResourceKind = "check"
+	KindGroup ResourceKind = "group"
+)
+
+// Operation is a single planned change against the Checkly API.
+type Operation struct {
+	Kind ResourceKind  `json:"kind"`
+	Op   OperationType `json:"op"`
+	// ID is the existing resource's identifier (Check.ID or
+	// strconv-formatted Group.ID). It is empty for OpCreate.
+	ID string `json:"id,omitempty"`
+	// Name is the resource's desired or current name, used for
+	// human-readable output.
+	Name string `json:"name"`
+
+	Check *checkly.Check `json:"check,omitempty"`
+	Group *checkly.Group `json:"group,omitempty"`
+
+	Reason string `json:"reason,omitempty"`
+}
+
+// Plan is an ordered list of Operations that reconciles current state
+// with desired state. Group creates/updates are ordered before the
+// checks that may depend on them; check deletes are ordered before
+// group deletes.
+type Plan struct {
+	Operations []Operation `json:"operations"`
+}
+
+// SyncOptions controls how Plan diffs desired state against current
+// state.
+type SyncOptions struct {
+	// ExternalIDLabel names a "key:value" tag on a Check or Group that
+	// holds the stable identifier used to match desired resources
+	// against current ones. If empty, or a resource has no matching tag,
+	// resources are matched by Name instead.
+	ExternalIDLabel string
+}
+
+// Lister is the subset of checkly.Client that NewPlan needs to fetch
+// current account state.
+type Lister interface {
+	GetChecks(ctx context.Context) ([]checkly.Check, error)
+	GetGroups(ctx context.Context) ([]checkly.Group, error)
+}
+
+func identity(name string, tags []string, opts SyncOptions) string {
+	if opts.ExternalIDLabel == "" {
+		return name
+	}
+	prefix := opts.ExternalIDLabel + ":"
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, prefix) {
+			return strings.TrimPrefix(tag, prefix)
+		}
+	}
+	return name
+}
+
+// NewPlan fetches current checks and groups, diffs them against desired by
+// ExternalIDLabel (or Name), and returns an ordered list of operations
+// that would reconcile the two.
+func NewPlan(
+	ctx context.Context,
+	client Lister,
+	desiredChecks []checkly.Check,
+	desiredGroups []checkly.Group,
+	opts SyncOptions,
+) (*Plan, error) {
+	currentGroups, err := client.GetGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sync: fetching current groups: %w", err)
+	}
+	currentChecks, err := client.GetChecks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sync: fetching current checks: %w", err)
+	}
+
+	groupOps := diffGroups(currentGroups, desiredGroups, opts)
+	checkOps := diffChecks(currentChecks, desiredChecks, opts)
+
+	plan := &Plan{}
+	for _, op := range groupOps {
+		if op.Op != OpDelete {
+			plan.Operations = append(plan.Operations, op)
+		}
+	}
+	for _, op := range checkOps {
+		if op.Op != OpDelete {
+			plan.Operations = append(plan.Operations, op)
+		}
+	}
+	for _, op := range checkOps {
+		if op.Op == OpDelete {
+			plan.Operations = append(plan.Operations, op)
+		}
+	}
+	for _, op := range groupOps {
+		if op.Op == OpDelete {
+			plan.Operations = append(plan.Operations, op)
+		}
+	}
+	return plan, nil
+}
+
+func diffChecks(current, desired []checkly.Check, opts SyncOptions) []Operation {
+	byIdentity := make(map[string]checkly.Check, len(current))
+	for _, c := range current {
+		byIdentity[identity(c.Name, c.Tags, opts)] = c
+	}
+
+	var ops []Operation
+	seen := make(map[string]bool, len(desired))
+	for _, want := range desired {
+		check := want
+		id := identity(check.Name, check.Tags, opts)
+		seen[id] = true
+		have, exists := byIdentity[id]
+		switch {
+		case !exists:
+			ops = append(ops, Operation{Kind: KindCheck, Op: OpCreate, Name: check.Name, Check: &check})
+		case checksEqual(have, check):
+			ops = append(ops, Operation{Kind: KindCheck, Op: OpNoOp, ID: have.ID, Name: check.Name, Check: &check})
+		default:
+			ops = append(ops, Operation{Kind: KindCheck, Op: OpUpdate, ID: have.ID, Name: check.Name, Check: &check, Reason: "desired state differs from current state"})
+		}
+	}
+	for id, have := range byIdentity {
+		if !seen[id] {
+			existing := have
+			ops = append(ops, Operation{Kind: KindCheck, Op: OpDelete, ID: have.ID, Name: have.Name, Check: &existing, Reason: "not present in desired state"})
+		}
+	}
+	return ops
+}
+
+func diffGroups(current, desired []checkly.Group, opts SyncOptions) []Operation {
+	byIdentity := make(map[string]checkly.Group, len(current))
+	for _, g := range current {
+		byIdentity[identity(g.Name, g.Tags, opts)] = g
+	}
+
+	var ops []Operation
+	seen := make(map[string]bool, len(desired))
+	for _, want := range desired {
+		group := want
+		id := identity(group.Name, group.Tags, opts)
+		seen[id] = true
+		have, exists := byIdentity[id]
+		switch {
+		case !exists:
+			ops = append(ops, Operation{Kind: KindGroup, Op: OpCreate, Name: group.Name, Group: &group})
+		case groupsEqual(have, group):
+			ops = append(ops, Operation{Kind: KindGroup, Op: OpNoOp, ID: fmt.Sprintf("%d", have.ID), Name: group.Name, Group: &group})
+		default:
+			ops = append(ops, Operation{Kind: KindGroup, Op: OpUpdate, ID: fmt.Sprintf("%d", have.ID), Name: group.Name, Group: &group, Reason: "desired state differs from current state"})
+		}
+	}
+	for id, have := range byIdentity {
+		if !seen[id] {
+			existing := have
+			ops = append(ops, Operation{Kind: KindGroup, Op: OpDelete, ID: fmt.Sprintf("%d", have.ID), Name: have.Name, Group: &existing, Reason: "not present in desired state"})
+		}
+	}
+	return ops
+}
+
+// checksEqual reports whether have already matches want, the caller's
+// desired check. Only fields want actually sets are compared; see
+// resourceEqual.
+func checksEqual(have, want checkly.Check) bool {
+	return resourceEqual(have, want)
+}
+
+// groupsEqual reports whether have already matches want, the caller's
+// desired group. Only fields want actually sets are compared; see
+// resourceEqual.
+func groupsEqual(have, want checkly.Group) bool {
+	return resourceEqual(have, want)
+}
+
+// resourceEqual compares have against want by projecting have down to
+// only the JSON fields want itself sets. Current resources returned by
+// the list endpoints carry server-assigned fields (IDs, timestamps,
+// computed subscription/group references, ...) that a caller's desired
+// object never sets; comparing the two wholesale would report a
+// difference on every already-correct resource. want is assumed to use
+// `omitempty` on its JSON tags, so a field it never touches round-trips
+// as absent rather than as a zero value to compare against.
+func resourceEqual(have, want interface{}) bool {
+	wantMap, err := toJSONMap(want)
+	if err != nil {
+		return false
+	}
+	haveMap, err := toJSONMap(have)
+	if err != nil {
+		return false
+	}
+	delete(wantMap, "id")
+	delete(haveMap, "id")
+
+	projected := make(map[string]interface{}, len(wantMap))
+	for k := range wantMap {
+		projected[k] = haveMap[k]
+	}
+
+	wantJSON, err := json.Marshal(wantMap)
+	if err != nil {
+		return false
+	}
+	projectedJSON, err := json.Marshal(projected)
+	if err != nil {
+		return false
+	}
+	return string(wantJSON) == string(projectedJSON)
+}
+
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// String renders the plan as a human-readable summary, one line per
+// operation.
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, op := range p.Operations {
+		switch op.Op {
+		case OpCreate:
+			fmt.Fprintf(&b, "+ create %s %q\n", op.Kind, op.Name)
+		case OpUpdate:
+			fmt.Fprintf(&b, "~ update %s %q (%s)\n", op.Kind, op.Name, op.Reason)
+		case OpDelete:
+			fmt.Fprintf(&b, "- delete %s %q (%s)\n", op.Kind, op.Name, op.Reason)
+		case OpNoOp:
+			fmt.Fprintf(&b, "  no-op  %s %q\n", op.Kind, op.Name)
+		}
+	}
+	return b.String()
+}
+
+// JSON renders the plan as indented JSON.
+func (p *Plan) JSON() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
@@ -0,0 +1,52 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type warningsContextKey struct{}
+
+// WithWarnings returns a context that causes client methods to append any
+// non-fatal warnings reported by the API (via the X-Checkly-Warning
+// header, or a top-level "warnings" field in the response body) to
+// warnings. This keeps warnings decoupled from errors: a call can return
+// a nil error and still have warnings worth surfacing.
+//
+//	var warnings []string
+//	check, err := client.Create(checkly.WithWarnings(ctx, &warnings), check)
+func WithWarnings(ctx context.Context, warnings *[]string) context.Context {
+	return context.WithValue(ctx, warningsContextKey{}, warnings)
+}
+
+func warningsSink(ctx context.Context) *[]string {
+	sink, _ := ctx.Value(warningsContextKey{}).(*[]string)
+	return sink
+}
+
+type warningsBody struct {
+	Warnings []string `json:"warnings"`
+}
+
+// extractWarnings pulls warnings out of a response's headers and, when
+// the body is a JSON object, its "warnings" field. Array-shaped bodies
+// (e.g. GetCheckResults) simply don't match and contribute nothing.
+func extractWarnings(resp *http.Response, body string) []string {
+	var warnings []string
+	if resp != nil {
+		if header := resp.Header.Get("X-Checkly-Warning"); header != "" {
+			for _, w := range strings.Split(header, ",") {
+				if w = strings.TrimSpace(w); w != "" {
+					warnings = append(warnings, w)
+				}
+			}
+		}
+	}
+	var parsed warningsBody
+	if json.Unmarshal([]byte(body), &parsed) == nil {
+		warnings = append(warnings, parsed.Warnings...)
+	}
+	return warnings
+}